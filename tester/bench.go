@@ -0,0 +1,283 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BenchConfig controls a load/benchmark run driven by runBenchmark.
+type BenchConfig struct {
+	Concurrency int
+	Duration    time.Duration
+	RPS         int // target requests per second across all workers, 0 = unlimited
+}
+
+// opStats summarizes one operation type's latency distribution and error
+// rate over the course of a benchmark run.
+type opStats struct {
+	Op         string
+	Count      int64 // successful ops; latency stats below are over this count only
+	Errors     int64
+	Min        time.Duration
+	Mean       time.Duration
+	Max        time.Duration
+	P50        time.Duration
+	P90        time.Duration
+	P99        time.Duration
+	P999       time.Duration
+	Throughput float64 // successful ops/sec
+}
+
+const histogramBuckets = 64
+
+// opHistogram is a fixed-size, log2-bucketed latency histogram modeled
+// loosely on HdrHistogram: recording is O(1) regardless of sample count,
+// which matters once a run pushes millions of operations. Bucket bounds are
+// powers of two in microseconds, so both sub-millisecond and multi-second
+// tails get usable resolution without storing every sample.
+type opHistogram struct {
+	mu      sync.Mutex
+	buckets [histogramBuckets]uint64
+	count   uint64
+	sum     time.Duration
+	min     time.Duration
+	max     time.Duration
+}
+
+func newOpHistogram() *opHistogram {
+	return &opHistogram{min: time.Duration(math.MaxInt64)}
+}
+
+func bucketFor(d time.Duration) int {
+	us := d.Microseconds()
+	if us < 1 {
+		return 0
+	}
+	b := int(math.Log2(float64(us))) + 1
+	if b >= histogramBuckets {
+		b = histogramBuckets - 1
+	}
+	return b
+}
+
+// bucketUpperBound returns the (exclusive) upper bound of bucket, i.e. the
+// value bucketFor maps to this bucket for all us in [2^(bucket-1), 2^bucket).
+func bucketUpperBound(bucket int) time.Duration {
+	if bucket <= 0 {
+		return time.Microsecond
+	}
+	return time.Duration(math.Pow(2, float64(bucket))) * time.Microsecond
+}
+
+func (h *opHistogram) record(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.buckets[bucketFor(d)]++
+	h.count++
+	h.sum += d
+	if d < h.min {
+		h.min = d
+	}
+	if d > h.max {
+		h.max = d
+	}
+}
+
+// percentile returns the upper bound of the bucket containing the p-th
+// percentile sample, 0 < p <= 100.
+func (h *opHistogram) percentile(p float64) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.count == 0 {
+		return 0
+	}
+
+	target := uint64(math.Ceil(p / 100 * float64(h.count)))
+	if target == 0 {
+		target = 1
+	}
+
+	var cumulative uint64
+	for i, c := range h.buckets {
+		cumulative += c
+		if cumulative >= target {
+			return bucketUpperBound(i)
+		}
+	}
+
+	return h.max
+}
+
+func (h *opHistogram) mean() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / time.Duration(h.count)
+}
+
+// runBenchmark drives create/get/delete against the orchestrator with
+// cfg.Concurrency workers for cfg.Duration, optionally rate-limited to
+// cfg.RPS, and returns per-operation latency and error statistics.
+func (t *Tester) runBenchmark(cfg BenchConfig) []opStats {
+	ops := []string{"create", "get", "delete"}
+	histograms := make(map[string]*opHistogram, len(ops))
+	errorCounts := make(map[string]*int64, len(ops))
+	for _, op := range ops {
+		histograms[op] = newOpHistogram()
+		errorCounts[op] = new(int64)
+	}
+
+	var limiter <-chan time.Time
+	if cfg.RPS > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(cfg.RPS))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	deadline := time.Now().Add(cfg.Duration)
+	var wg sync.WaitGroup
+
+	for i := 0; i < cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			for time.Now().Before(deadline) {
+				if limiter != nil {
+					<-limiter
+				}
+
+				data := map[string]interface{}{"worker": worker}
+
+				opStart := time.Now()
+				session, err := t.createSession(data)
+				if err != nil {
+					atomic.AddInt64(errorCounts["create"], 1)
+					continue
+				}
+				histograms["create"].record(time.Since(opStart))
+
+				if limiter != nil {
+					<-limiter
+				}
+
+				opStart = time.Now()
+				_, err = t.getSession(session.ID)
+				if err != nil {
+					atomic.AddInt64(errorCounts["get"], 1)
+				} else {
+					histograms["get"].record(time.Since(opStart))
+				}
+
+				if limiter != nil {
+					<-limiter
+				}
+
+				opStart = time.Now()
+				err = t.deleteSession(session.ID)
+				if err != nil {
+					atomic.AddInt64(errorCounts["delete"], 1)
+				} else {
+					histograms["delete"].record(time.Since(opStart))
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	stats := make([]opStats, 0, len(ops))
+	for _, op := range ops {
+		h := histograms[op]
+		errs := atomic.LoadInt64(errorCounts[op])
+		count := int64(h.count)
+
+		min, max := h.min, h.max
+		if count == 0 {
+			min, max = 0, 0
+		}
+
+		stats = append(stats, opStats{
+			Op:         op,
+			Count:      count,
+			Errors:     errs,
+			Min:        min,
+			Mean:       h.mean(),
+			Max:        max,
+			P50:        h.percentile(50),
+			P90:        h.percentile(90),
+			P99:        h.percentile(99),
+			P999:       h.percentile(99.9),
+			Throughput: float64(count) / cfg.Duration.Seconds(),
+		})
+	}
+
+	return stats
+}
+
+func printBenchResults(stats []opStats) {
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━ 📈 BENCHMARK RESULTS ━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("%-8s %8s %8s %7s %10s %10s %10s %10s %10s\n",
+		"OP", "COUNT", "ERRORS", "ERR%", "P50", "P90", "P99", "P999", "OPS/SEC")
+
+	for _, s := range stats {
+		errRate := 0.0
+		if total := s.Count + s.Errors; total > 0 {
+			errRate = float64(s.Errors) / float64(total) * 100
+		}
+		fmt.Printf("%-8s %8d %8d %6.2f%% %10s %10s %10s %10s %10.1f\n",
+			s.Op, s.Count, s.Errors, errRate, s.P50, s.P90, s.P99, s.P999, s.Throughput)
+	}
+
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+}
+
+// writeBenchCSV dumps per-operation latency statistics to path for offline
+// capacity-planning analysis (e.g. tracking p99 drift across runs).
+func writeBenchCSV(stats []opStats, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	header := []string{"op", "count", "errors", "min_us", "mean_us", "max_us", "p50_us", "p90_us", "p99_us", "p999_us", "ops_per_sec"}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, s := range stats {
+		record := []string{
+			s.Op,
+			strconv.FormatInt(s.Count, 10),
+			strconv.FormatInt(s.Errors, 10),
+			strconv.FormatInt(s.Min.Microseconds(), 10),
+			strconv.FormatInt(s.Mean.Microseconds(), 10),
+			strconv.FormatInt(s.Max.Microseconds(), 10),
+			strconv.FormatInt(s.P50.Microseconds(), 10),
+			strconv.FormatInt(s.P90.Microseconds(), 10),
+			strconv.FormatInt(s.P99.Microseconds(), 10),
+			strconv.FormatInt(s.P999.Microseconds(), 10),
+			strconv.FormatFloat(s.Throughput, 'f', 1, 64),
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return w.Error()
+}
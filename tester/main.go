@@ -5,9 +5,9 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
-	"io"
 	"net/http"
-	"sync"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,6 +15,24 @@ type Session struct {
 	ID        string                 `json:"id"`
 	CreatedAt int64                  `json:"created_at"`
 	Data      map[string]interface{} `json:"data"`
+	LockDelay time.Duration          `json:"lock_delay,omitempty"`
+	Behavior  string                 `json:"behavior,omitempty"`
+	Checks    []string               `json:"checks,omitempty"`
+	WorkerID  string                 `json:"worker_id,omitempty"`
+}
+
+// SessionOptions carries Consul-style invalidation semantics for a session:
+// LockDelay keeps any lock it held from being reacquired for a grace period
+// after invalidation, and Behavior controls whether the resources it held
+// are released back to the pool ("release") or torn down ("delete").
+type SessionOptions struct {
+	LockDelay time.Duration
+	Behavior  string
+	Checks    []string
+}
+
+type RenewResponse struct {
+	ExpiresAt int64 `json:"expires_at"`
 }
 
 type StatusResponse struct {
@@ -27,21 +45,26 @@ type TestResult struct {
 	Passed  bool
 	Error   string
 	Elapsed time.Duration
+	// Info holds supplementary detail printed alongside the pass/fail line
+	// regardless of outcome, e.g. per-session failover timings.
+	Info string
 }
 
 type Tester struct {
-	baseURL string
-	client  *http.Client
-	results []TestResult
+	baseURL   string
+	client    *http.Client
+	transport Transport
+	results   []TestResult
 }
 
-func NewTester(baseURL string) *Tester {
+func NewTester(baseURL string, transport Transport) *Tester {
 	return &Tester{
 		baseURL: baseURL,
 		client: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		results: []TestResult{},
+		transport: transport,
+		results:   []TestResult{},
 	}
 }
 
@@ -54,8 +77,39 @@ func (t *Tester) recordResult(name string, passed bool, err string, elapsed time
 	})
 }
 
+func (t *Tester) recordResultWithInfo(name string, passed bool, err, info string, elapsed time.Duration) {
+	t.results = append(t.results, TestResult{
+		Name:    name,
+		Passed:  passed,
+		Error:   err,
+		Info:    info,
+		Elapsed: elapsed,
+	})
+}
+
+// createSession, getSession, deleteSession, and getHealth are protocol-
+// agnostic: they delegate to whichever Transport the Tester was built with,
+// so every test below makes those calls identically over REST, gRPC, or
+// WebSocket. createSessionWithOptions, acquireLock, updateSession,
+// watchSession, injectFault, and renewSession always use the REST client.
 func (t *Tester) createSession(data map[string]interface{}) (*Session, error) {
-	body, _ := json.Marshal(data)
+	return t.transport.Create(data)
+}
+
+func (t *Tester) createSessionWithOptions(data map[string]interface{}, opts SessionOptions) (*Session, error) {
+	req := struct {
+		Data      map[string]interface{} `json:"data"`
+		LockDelay time.Duration          `json:"lock_delay,omitempty"`
+		Behavior  string                 `json:"behavior,omitempty"`
+		Checks    []string               `json:"checks,omitempty"`
+	}{
+		Data:      data,
+		LockDelay: opts.LockDelay,
+		Behavior:  opts.Behavior,
+		Checks:    opts.Checks,
+	}
+
+	body, _ := json.Marshal(req)
 	resp, err := t.client.Post(t.baseURL+"/sessions", "application/json", bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
@@ -74,8 +128,46 @@ func (t *Tester) createSession(data map[string]interface{}) (*Session, error) {
 	return &session, nil
 }
 
+type lockAcquireResponse struct {
+	Acquired bool `json:"acquired"`
+}
+
+func (t *Tester) acquireLock(sessionID string) (bool, error) {
+	resp, err := t.client.Post(t.baseURL+"/sessions/"+sessionID+"/acquire", "application/json", nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusConflict {
+		return false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result lockAcquireResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, err
+	}
+
+	return result.Acquired, nil
+}
+
 func (t *Tester) getSession(id string) (*Session, error) {
-	resp, err := t.client.Get(t.baseURL + "/sessions/" + id)
+	return t.transport.Get(id)
+}
+
+func (t *Tester) updateSession(id string, data map[string]interface{}) (*Session, error) {
+	body, _ := json.Marshal(map[string]interface{}{"data": data})
+	req, err := http.NewRequest(http.MethodPut, t.baseURL+"/sessions/"+id, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -97,46 +189,99 @@ func (t *Tester) getSession(id string) (*Session, error) {
 	return &session, nil
 }
 
-func (t *Tester) deleteSession(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, t.baseURL+"/sessions/"+id, nil)
-	if err != nil {
-		return err
-	}
+// watchSession performs a blocking query for a session's status, mirroring
+// Consul's watch pattern: index is the last-seen X-Session-Index (0 for an
+// initial, non-blocking read), and the server holds the connection open for
+// up to wait until the session's modify index advances past it.
+func (t *Tester) watchSession(id string, index int64, wait time.Duration) (*Session, int64, error) {
+	watchClient := &http.Client{Timeout: wait + 5*time.Second}
 
-	resp, err := t.client.Do(req)
+	url := fmt.Sprintf("%s/sessions/%s?index=%d&wait=%s", t.baseURL, id, index, wait)
+	resp, err := watchClient.Get(url)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("session not found")
+		return nil, 0, fmt.Errorf("session not found")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	newIndex, err := strconv.ParseInt(resp.Header.Get("X-Session-Index"), 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid X-Session-Index header: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+	var session Session
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return nil, 0, err
+	}
+
+	return &session, newIndex, nil
+}
+
+func (t *Tester) deleteSession(id string) error {
+	return t.transport.Delete(id)
+}
+
+type faultRequest struct {
+	WorkerID string        `json:"worker_id"`
+	Action   string        `json:"action"`
+	Duration time.Duration `json:"duration"`
+}
+
+// injectFault asks the orchestrator to simulate a worker failure ("kill",
+// "pause", or "netsplit") for the given duration, so tests can drive and
+// observe failover without needing a real outage.
+func (t *Tester) injectFault(workerID, action string, duration time.Duration) error {
+	body, _ := json.Marshal(faultRequest{WorkerID: workerID, Action: action, Duration: duration})
+	resp, err := t.client.Post(t.baseURL+"/admin/faults", "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
 		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
 	return nil
 }
 
-func (t *Tester) getHealth() error {
-	resp, err := t.client.Get(t.baseURL + "/health")
+func (t *Tester) renewSession(id string) (int64, error) {
+	req, err := http.NewRequest(http.MethodPut, t.baseURL+"/sessions/"+id+"/renew", nil)
 	if err != nil {
-		return err
+		return 0, err
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return 0, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, fmt.Errorf("session not found")
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	body, _ := io.ReadAll(resp.Body)
-	if string(body) != "ok" && string(body) != `"ok"` {
-		return fmt.Errorf("unexpected response: %s", string(body))
+	var renewed RenewResponse
+	if err := json.NewDecoder(resp.Body).Decode(&renewed); err != nil {
+		return 0, err
 	}
 
-	return nil
+	return renewed.ExpiresAt, nil
+}
+
+func (t *Tester) getHealth() error {
+	return t.transport.Health()
 }
 
 func (t *Tester) testCreateSession() {
@@ -237,101 +382,250 @@ func (t *Tester) test404OnMissing() {
 	t.recordResult("404 on missing session", true, "", elapsed)
 }
 
-func (t *Tester) testConcurrentSessions() {
+func (t *Tester) testSessionTTL() {
 	start := time.Now()
-	const numSessions = 10
-	var wg sync.WaitGroup
-	errors := make(chan error, numSessions)
 
-	for i := 0; i < numSessions; i++ {
-		wg.Add(1)
-		go func(idx int) {
-			defer wg.Done()
+	// Create a session
+	session, err := t.createSession(map[string]interface{}{"user": "ttl-test"})
+	if err != nil {
+		t.recordResult("Session TTL expiration (60s)", false, "failed to create session: "+err.Error(), time.Since(start))
+		return
+	}
 
-			session, err := t.createSession(map[string]interface{}{"user": fmt.Sprintf("user%d", idx)})
-			if err != nil {
-				errors <- err
-				return
-			}
+	fmt.Println("   Waiting 60 seconds for TTL expiration...")
+	time.Sleep(61 * time.Second)
 
-			retrieved, err := t.getSession(session.ID)
-			if err != nil {
-				errors <- err
-				return
-			}
+	// Try to get the session
+	_, err = t.getSession(session.ID)
+	elapsed := time.Since(start)
 
-			if retrieved.ID != session.ID {
-				errors <- fmt.Errorf("session ID mismatch")
-				return
-			}
-		}(i)
+	if err == nil {
+		t.recordResult("Session TTL expiration (60s)", false, "session still exists after TTL", elapsed)
+		return
+	}
+
+	t.recordResult("Session TTL expiration (60s)", true, "", elapsed)
+}
+
+func (t *Tester) testRenewSession() {
+	start := time.Now()
+
+	session, err := t.createSession(map[string]interface{}{"user": "renew-test"})
+	if err != nil {
+		t.recordResult("Renew session", false, "failed to create session: "+err.Error(), time.Since(start))
+		return
+	}
+
+	firstExpiry, err := t.renewSession(session.ID)
+	if err != nil {
+		t.recordResult("Renew session", false, err.Error(), time.Since(start))
+		return
+	}
+
+	// Renew again well inside the 60s TTL and confirm the expiry keeps
+	// moving forward instead of the session lapsing.
+	time.Sleep(40 * time.Second)
+
+	secondExpiry, err := t.renewSession(session.ID)
+	if err != nil {
+		t.recordResult("Renew session", false, err.Error(), time.Since(start))
+		return
+	}
+
+	if secondExpiry <= firstExpiry {
+		t.recordResult("Renew session", false, "renew did not extend expiry", time.Since(start))
+		return
+	}
+
+	time.Sleep(25 * time.Second)
+
+	if _, err := t.getSession(session.ID); err != nil {
+		t.recordResult("Renew session", false, "session expired despite renewal: "+err.Error(), time.Since(start))
+		return
 	}
 
-	wg.Wait()
-	close(errors)
 	elapsed := time.Since(start)
 
-	errCount := 0
-	var lastErr error
-	for err := range errors {
-		errCount++
-		lastErr = err
+	if _, err := t.renewSession("invalid-session-id"); err == nil {
+		t.recordResult("Renew session", false, "expected 404 renewing unknown session", elapsed)
+		return
+	}
+
+	t.recordResult("Renew session", true, "", elapsed)
+}
+
+func (t *Tester) testLockDelayBehavior() {
+	start := time.Now()
+
+	session, err := t.createSessionWithOptions(
+		map[string]interface{}{"user": "lock-test"},
+		SessionOptions{LockDelay: 20 * time.Second, Behavior: "delete"},
+	)
+	if err != nil {
+		t.recordResult("Lock delay + behavior semantics", false, "failed to create session: "+err.Error(), time.Since(start))
+		return
+	}
+
+	if ok, err := t.acquireLock(session.ID); err != nil || !ok {
+		t.recordResult("Lock delay + behavior semantics", false, "failed to acquire initial lock", time.Since(start))
+		return
+	}
+
+	// Force invalidation; with Behavior "delete" the resources the session
+	// held should be destroyed rather than released back to the pool.
+	if err := t.deleteSession(session.ID); err != nil {
+		t.recordResult("Lock delay + behavior semantics", false, "failed to invalidate session: "+err.Error(), time.Since(start))
+		return
 	}
 
-	if errCount > 0 {
-		t.recordResult("Concurrent sessions (10 parallel)", false,
-			fmt.Sprintf("%d errors, last: %v", errCount, lastErr), elapsed)
+	// The lock delay must block reacquisition for the configured window,
+	// even immediately after invalidation. acquireLock only returns
+	// ok=false, err=nil on a 409 Conflict (the lock-delay rejection) or a
+	// 200 reporting acquired=false - any other error (e.g. a 404 because the session is simply gone) means we
+	// can't tell the delay apart from the session having vanished, so it
+	// must fail the test rather than pass it by default.
+	ok, err := t.acquireLock(session.ID)
+	if err != nil {
+		t.recordResult("Lock delay + behavior semantics", false, "acquire during lock delay returned unexpected error (want 409 conflict): "+err.Error(), time.Since(start))
+		return
+	}
+	if ok {
+		t.recordResult("Lock delay + behavior semantics", false, "lock reacquired before lock delay elapsed", time.Since(start))
 		return
 	}
 
-	t.recordResult("Concurrent sessions (10 parallel)", true, "", elapsed)
+	t.recordResult("Lock delay + behavior semantics", true, "", time.Since(start))
 }
 
-func (t *Tester) testSessionTTL() {
+func (t *Tester) testSessionWatch() {
 	start := time.Now()
 
-	// Create a session
-	session, err := t.createSession(map[string]interface{}{"user": "ttl-test"})
+	session, err := t.createSession(map[string]interface{}{"user": "watch-test", "status": "pending"})
 	if err != nil {
-		t.recordResult("Session TTL expiration (60s)", false, "failed to create session: "+err.Error(), time.Since(start))
+		t.recordResult("Session watch (blocking query)", false, "failed to create session: "+err.Error(), time.Since(start))
 		return
 	}
 
-	fmt.Println("   Waiting 60 seconds for TTL expiration...")
-	time.Sleep(61 * time.Second)
+	// A zero wait returns immediately and gives us the baseline index to
+	// watch from.
+	_, baseIndex, err := t.watchSession(session.ID, 0, 0)
+	if err != nil {
+		t.recordResult("Session watch (blocking query)", false, "failed to fetch baseline index: "+err.Error(), time.Since(start))
+		return
+	}
 
-	// Try to get the session
-	_, err = t.getSession(session.ID)
-	elapsed := time.Since(start)
+	type watchOutcome struct {
+		session *Session
+		err     error
+	}
+	watchDone := make(chan watchOutcome, 1)
 
-	if err == nil {
-		t.recordResult("Session TTL expiration (60s)", false, "session still exists after TTL", elapsed)
+	go func() {
+		updated, _, err := t.watchSession(session.ID, baseIndex, 30*time.Second)
+		watchDone <- watchOutcome{updated, err}
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+	if _, err := t.updateSession(session.ID, map[string]interface{}{"user": "watch-test", "status": "done"}); err != nil {
+		t.recordResult("Session watch (blocking query)", false, "failed to update session: "+err.Error(), time.Since(start))
 		return
 	}
 
-	t.recordResult("Session TTL expiration (60s)", true, "", elapsed)
+	select {
+	case result := <-watchDone:
+		elapsed := time.Since(start)
+		if result.err != nil {
+			t.recordResult("Session watch (blocking query)", false, result.err.Error(), elapsed)
+			return
+		}
+		if result.session.Data["status"] != "done" {
+			t.recordResult("Session watch (blocking query)", false, "watch returned stale data", elapsed)
+			return
+		}
+		t.recordResult("Session watch (blocking query)", true, "", elapsed)
+	case <-time.After(5 * time.Second):
+		t.recordResult("Session watch (blocking query)", false, "watch did not unblock within bounded time", time.Since(start))
+	}
 }
 
 func (t *Tester) testWorkerFailureRecovery() {
 	start := time.Now()
+	const numSessions = 6
+	const recoveryWindow = 10 * time.Second
+	const pollInterval = 200 * time.Millisecond
 
-	// This is a placeholder - actual implementation would depend on how worker failures are simulated
-	// For now, we'll just create and retrieve a session to verify basic functionality
-	session, err := t.createSession(map[string]interface{}{"user": "recovery-test"})
-	if err != nil {
-		t.recordResult("Worker failure recovery", false, err.Error(), time.Since(start))
+	type trackedSession struct {
+		id       string
+		workerID string
+	}
+
+	sessions := make([]trackedSession, 0, numSessions)
+	for i := 0; i < numSessions; i++ {
+		session, err := t.createSession(map[string]interface{}{"user": fmt.Sprintf("failover-%d", i)})
+		if err != nil {
+			t.recordResultWithInfo("Worker failure recovery", false, "failed to create session: "+err.Error(), "", time.Since(start))
+			return
+		}
+		sessions = append(sessions, trackedSession{id: session.ID, workerID: session.WorkerID})
+	}
+
+	// Kill whichever worker the first session landed on; every session
+	// sharing that worker is expected to either fail over or be invalidated.
+	targetWorker := sessions[0].workerID
+	if targetWorker == "" {
+		t.recordResultWithInfo("Worker failure recovery", false, "sessions did not report a worker_id", "", time.Since(start))
 		return
 	}
 
-	_, err = t.getSession(session.ID)
+	if err := t.injectFault(targetWorker, "kill", recoveryWindow); err != nil {
+		t.recordResultWithInfo("Worker failure recovery", false, "failed to inject fault: "+err.Error(), "", time.Since(start))
+		return
+	}
+
+	var recoveryLines []string
+	lost := 0
+
+	for _, s := range sessions {
+		if s.workerID != targetWorker {
+			continue
+		}
+
+		faultStart := time.Now()
+		var recoveredTo, invalidReason string
+
+		for time.Since(faultStart) < recoveryWindow {
+			retrieved, err := t.getSession(s.id)
+			if err != nil {
+				invalidReason = err.Error()
+				break
+			}
+			if retrieved.WorkerID != targetWorker {
+				recoveredTo = retrieved.WorkerID
+				break
+			}
+			time.Sleep(pollInterval)
+		}
+
+		switch {
+		case invalidReason != "":
+			recoveryLines = append(recoveryLines, fmt.Sprintf("%s invalidated in %v (%s)", s.id, time.Since(faultStart).Round(time.Millisecond), invalidReason))
+		case recoveredTo != "":
+			recoveryLines = append(recoveryLines, fmt.Sprintf("%s failed over to %s in %v", s.id, recoveredTo, time.Since(faultStart).Round(time.Millisecond)))
+		default:
+			lost++
+			recoveryLines = append(recoveryLines, fmt.Sprintf("%s LOST (still on %s after %v)", s.id, targetWorker, recoveryWindow))
+		}
+	}
+
 	elapsed := time.Since(start)
+	info := strings.Join(recoveryLines, "; ")
 
-	if err != nil {
-		t.recordResult("Worker failure recovery", false, err.Error(), elapsed)
+	if lost > 0 {
+		t.recordResultWithInfo("Worker failure recovery", false, fmt.Sprintf("%d session(s) lost after worker kill", lost), info, elapsed)
 		return
 	}
 
-	t.recordResult("Worker failure recovery", true, "", elapsed)
+	t.recordResultWithInfo("Worker failure recovery", true, "", info, elapsed)
 }
 
 func (t *Tester) runAllTests() {
@@ -342,8 +636,10 @@ func (t *Tester) runAllTests() {
 	t.testGetSession()
 	t.testDeleteSession()
 	t.test404OnMissing()
-	t.testConcurrentSessions()
 	t.testSessionTTL()
+	t.testRenewSession()
+	t.testLockDelayBehavior()
+	t.testSessionWatch()
 	t.testWorkerFailureRecovery()
 }
 
@@ -358,6 +654,9 @@ func (t *Tester) printResults() {
 		} else {
 			fmt.Printf("✗ %s: %s\n", result.Name, result.Error)
 		}
+		if result.Info != "" {
+			fmt.Printf("   %s\n", result.Info)
+		}
 	}
 
 	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━ 📊 RESULTS: %d/%d passed ━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n", passed, total)
@@ -374,9 +673,21 @@ func (t *Tester) printResults() {
 
 func main() {
 	url := flag.String("url", "http://localhost:8080", "Base URL of the orchestrator API")
+	bench := flag.Bool("bench", false, "Run the load/benchmark harness instead of the correctness test suite")
+	concurrency := flag.Int("concurrency", 10, "Number of concurrent workers (bench mode)")
+	duration := flag.Duration("duration", 30*time.Second, "How long to run the benchmark (bench mode)")
+	rps := flag.Int("rps", 0, "Target requests per second across all workers, 0 for unlimited (bench mode)")
+	csvOut := flag.String("csv", "bench_results.csv", "Path to write per-operation latency CSV (bench mode)")
+	transportKind := flag.String("transport", "http", "Transport to drive the orchestrator over: http, grpc, or ws")
 	flag.Parse()
 
-	tester := NewTester(*url)
+	transport, err := newTransport(*transportKind, *url)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	tester := NewTester(*url, transport)
 
 	// Test health endpoint first
 	fmt.Printf("Testing connection to %s...\n", *url)
@@ -387,6 +698,19 @@ func main() {
 	}
 	fmt.Println()
 
+	if *bench {
+		cfg := BenchConfig{Concurrency: *concurrency, Duration: *duration, RPS: *rps}
+		fmt.Printf("Running benchmark: concurrency=%d duration=%s rps=%d\n", cfg.Concurrency, cfg.Duration, cfg.RPS)
+		stats := tester.runBenchmark(cfg)
+		printBenchResults(stats)
+		if err := writeBenchCSV(stats, *csvOut); err != nil {
+			fmt.Printf("failed to write CSV: %v\n", err)
+			return
+		}
+		fmt.Printf("Wrote per-operation latencies to %s\n", *csvOut)
+		return
+	}
+
 	tester.runAllTests()
 	tester.printResults()
 }
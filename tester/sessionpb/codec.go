@@ -0,0 +1,31 @@
+package sessionpb
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// jsonCodec implements grpc/encoding.Codec. SessionService's messages are
+// plain structs with json tags rather than protoc-generated proto.Message
+// types, so they can't go through grpc's default "proto" codec (which
+// requires ProtoReflect); registering "json" and dialing with
+// grpc.CallContentSubtype("json") makes the client negotiate this codec
+// for every call instead.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
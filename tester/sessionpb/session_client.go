@@ -0,0 +1,66 @@
+// Hand-maintained SessionService client, mirroring what protoc-gen-go-grpc
+// would emit from proto/session.proto. There's no protoc toolchain in this
+// build, and the messages it calls with aren't real proto.Message values
+// (see session_messages.go), so this is kept in sync by hand instead of
+// regenerated.
+package sessionpb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	SessionService_Create_FullMethodName = "/session.SessionService/Create"
+	SessionService_Get_FullMethodName    = "/session.SessionService/Get"
+	SessionService_Delete_FullMethodName = "/session.SessionService/Delete"
+	SessionService_Health_FullMethodName = "/session.SessionService/Health"
+)
+
+type SessionServiceClient interface {
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*SessionReply, error)
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*SessionReply, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteReply, error)
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthReply, error)
+}
+
+type sessionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSessionServiceClient(cc grpc.ClientConnInterface) SessionServiceClient {
+	return &sessionServiceClient{cc}
+}
+
+func (c *sessionServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*SessionReply, error) {
+	out := new(SessionReply)
+	if err := c.cc.Invoke(ctx, SessionService_Create_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*SessionReply, error) {
+	out := new(SessionReply)
+	if err := c.cc.Invoke(ctx, SessionService_Get_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteReply, error) {
+	out := new(DeleteReply)
+	if err := c.cc.Invoke(ctx, SessionService_Delete_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthReply, error) {
+	out := new(HealthReply)
+	if err := c.cc.Invoke(ctx, SessionService_Health_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,37 @@
+// Package sessionpb defines the wire messages for SessionService (see
+// ../proto/session.proto). These are hand-maintained rather than run
+// through protoc: the service is driven over gRPC's "json" content
+// subtype (registered in codec.go), so plain structs with json tags are
+// sufficient and there is no generated proto.Message/ProtoReflect to keep
+// in sync.
+package sessionpb
+
+type CreateRequest struct {
+	Data []byte `json:"data,omitempty"`
+}
+
+type GetRequest struct {
+	Id string `json:"id,omitempty"`
+}
+
+type DeleteRequest struct {
+	Id string `json:"id,omitempty"`
+}
+
+type DeleteReply struct{}
+
+type HealthRequest struct{}
+
+type HealthReply struct {
+	Ok bool `json:"ok,omitempty"`
+}
+
+type SessionReply struct {
+	Id        string   `json:"id,omitempty"`
+	CreatedAt int64    `json:"created_at,omitempty"`
+	Data      []byte   `json:"data,omitempty"`
+	WorkerId  string   `json:"worker_id,omitempty"`
+	LockDelay int64    `json:"lock_delay,omitempty"` // nanoseconds, matches Session.LockDelay's default json encoding
+	Behavior  string   `json:"behavior,omitempty"`
+	Checks    []string `json:"checks,omitempty"`
+}
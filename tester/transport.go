@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Transport abstracts the wire protocol used to reach the orchestrator for
+// create/get/delete/health, so those four operations can be driven over the
+// REST API, gRPC, or a multiplexed WebSocket connection via -transport.
+// Session features layered on afterwards - renew, lock-delay create/acquire,
+// update, watch, and fault injection - have no gRPC/WS equivalents and
+// always go over Tester's own REST client against -url regardless of
+// -transport. Every create/get/delete/health call made by the tests and by
+// the benchmark goes over the selected Transport; only
+// testCreateSession/testGetSession/testDeleteSession/test404OnMissing use
+// nothing else.
+type Transport interface {
+	Create(data map[string]interface{}) (*Session, error)
+	Get(id string) (*Session, error)
+	Delete(id string) error
+	Health() error
+}
+
+// newTransport builds the Transport named by kind ("http", "grpc", or
+// "ws"), all pointed at the same addr. addr is always the REST-style base
+// URL (e.g. "http://localhost:8080") also used as -url; transports that
+// need a different wire format (grpc wants a bare host:port) derive it
+// from addr themselves rather than requiring a different flag value per
+// -transport.
+func newTransport(kind, addr string) (Transport, error) {
+	switch kind {
+	case "", "http":
+		return newHTTPTransport(addr), nil
+	case "grpc":
+		return newGRPCTransport(addr)
+	case "ws":
+		return newWSTransport(addr)
+	default:
+		return nil, fmt.Errorf("unknown transport %q (want http, grpc, or ws)", kind)
+	}
+}
+
+// httpTransport is the original REST transport: one connection per request
+// against the orchestrator's JSON HTTP API.
+type httpTransport struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newHTTPTransport(baseURL string) *httpTransport {
+	return &httpTransport{
+		baseURL: baseURL,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (h *httpTransport) Create(data map[string]interface{}) (*Session, error) {
+	body, _ := json.Marshal(data)
+	resp, err := h.client.Post(h.baseURL+"/sessions", "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var session Session
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (h *httpTransport) Get(id string) (*Session, error) {
+	resp, err := h.client.Get(h.baseURL + "/sessions/" + id)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("session not found")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var session Session
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (h *httpTransport) Delete(id string) error {
+	req, err := http.NewRequest(http.MethodDelete, h.baseURL+"/sessions/"+id, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("session not found")
+	}
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (h *httpTransport) Health() error {
+	resp, err := h.client.Get(h.baseURL + "/health")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "ok" && string(body) != `"ok"` {
+		return fmt.Errorf("unexpected response: %s", string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/rahulk789/browser-orchestrator/tester/sessionpb"
+)
+
+// grpcTransport drives the orchestrator's SessionService (see
+// proto/session.proto) over a single persistent gRPC connection, avoiding
+// the per-request TCP/TLS setup of the REST transport.
+type grpcTransport struct {
+	conn   *grpc.ClientConn
+	client sessionpb.SessionServiceClient
+}
+
+// newGRPCTransport accepts the same "http://host:port"-style address used
+// by the other transports and strips the scheme grpc.NewClient doesn't
+// want, so -url doesn't need a transport-specific format.
+func newGRPCTransport(addr string) (*grpcTransport, error) {
+	target := strings.TrimPrefix(strings.TrimPrefix(addr, "https://"), "http://")
+
+	conn, err := grpc.NewClient(
+		target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype("json")),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dial grpc %s: %w", target, err)
+	}
+
+	return &grpcTransport{
+		conn:   conn,
+		client: sessionpb.NewSessionServiceClient(conn),
+	}, nil
+}
+
+func (g *grpcTransport) callCtx() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+func (g *grpcTransport) Create(data map[string]interface{}) (*Session, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := g.callCtx()
+	defer cancel()
+
+	reply, err := g.client.Create(ctx, &sessionpb.CreateRequest{Data: encoded})
+	if err != nil {
+		return nil, err
+	}
+
+	return sessionFromReply(reply)
+}
+
+func (g *grpcTransport) Get(id string) (*Session, error) {
+	ctx, cancel := g.callCtx()
+	defer cancel()
+
+	reply, err := g.client.Get(ctx, &sessionpb.GetRequest{Id: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("session not found")
+		}
+		return nil, err
+	}
+
+	return sessionFromReply(reply)
+}
+
+func (g *grpcTransport) Delete(id string) error {
+	ctx, cancel := g.callCtx()
+	defer cancel()
+
+	_, err := g.client.Delete(ctx, &sessionpb.DeleteRequest{Id: id})
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("session not found")
+	}
+	return err
+}
+
+func (g *grpcTransport) Health() error {
+	ctx, cancel := g.callCtx()
+	defer cancel()
+
+	reply, err := g.client.Health(ctx, &sessionpb.HealthRequest{})
+	if err != nil {
+		return err
+	}
+	if !reply.Ok {
+		return fmt.Errorf("orchestrator reported unhealthy")
+	}
+
+	return nil
+}
+
+// sessionFromReply maps every field of a SessionReply onto a Session, not
+// just id/created_at/data - WorkerID in particular is read by
+// testWorkerFailureRecovery regardless of which transport is selected.
+func sessionFromReply(reply *sessionpb.SessionReply) (*Session, error) {
+	session := &Session{
+		ID:        reply.Id,
+		CreatedAt: reply.CreatedAt,
+		WorkerID:  reply.WorkerId,
+		LockDelay: time.Duration(reply.LockDelay),
+		Behavior:  reply.Behavior,
+		Checks:    reply.Checks,
+	}
+
+	if len(reply.Data) > 0 {
+		if err := json.Unmarshal(reply.Data, &session.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	return session, nil
+}
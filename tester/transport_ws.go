@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsRequest and wsResponse are the envelope multiplexed over the single
+// WebSocket connection: each request carries a unique ID so the read loop
+// can route its response back to the caller that's waiting on it, even
+// though calls arrive (and complete) out of order.
+type wsRequest struct {
+	ID      string          `json:"id"`
+	Op      string          `json:"op"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type wsResponse struct {
+	ID      string          `json:"id"`
+	Error   string          `json:"error,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// wsTransport multiplexes Create/Get/Delete/Health over one persistent
+// WebSocket connection instead of opening a connection per request.
+type wsTransport struct {
+	conn    *websocket.Conn
+	nextID  uint64
+	mu      sync.Mutex
+	pending map[string]chan wsResponse
+}
+
+func newWSTransport(baseURL string) (*wsTransport, error) {
+	wsURL := strings.Replace(strings.Replace(baseURL, "http://", "ws://", 1), "https://", "wss://", 1)
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL+"/ws", nil)
+	if err != nil {
+		return nil, fmt.Errorf("dial ws %s: %w", wsURL, err)
+	}
+
+	t := &wsTransport{
+		conn:    conn,
+		pending: make(map[string]chan wsResponse),
+	}
+	go t.readLoop()
+
+	return t, nil
+}
+
+func (t *wsTransport) readLoop() {
+	for {
+		var resp wsResponse
+		if err := t.conn.ReadJSON(&resp); err != nil {
+			t.failAllPending(err)
+			return
+		}
+
+		t.mu.Lock()
+		ch, ok := t.pending[resp.ID]
+		delete(t.pending, resp.ID)
+		t.mu.Unlock()
+
+		if ok {
+			ch <- resp
+		}
+	}
+}
+
+func (t *wsTransport) failAllPending(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for id, ch := range t.pending {
+		ch <- wsResponse{ID: id, Error: fmt.Sprintf("connection closed: %v", err)}
+		delete(t.pending, id)
+	}
+}
+
+func (t *wsTransport) call(op string, payload interface{}) (wsResponse, error) {
+	id := strconv.FormatUint(atomic.AddUint64(&t.nextID, 1), 10)
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return wsResponse{}, err
+	}
+
+	ch := make(chan wsResponse, 1)
+	t.mu.Lock()
+	t.pending[id] = ch
+	t.mu.Unlock()
+
+	t.mu.Lock()
+	writeErr := t.conn.WriteJSON(wsRequest{ID: id, Op: op, Payload: encoded})
+	t.mu.Unlock()
+	if writeErr != nil {
+		t.mu.Lock()
+		delete(t.pending, id)
+		t.mu.Unlock()
+		return wsResponse{}, writeErr
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.Error != "" {
+			return wsResponse{}, fmt.Errorf(resp.Error)
+		}
+		return resp, nil
+	case <-time.After(10 * time.Second):
+		t.mu.Lock()
+		delete(t.pending, id)
+		t.mu.Unlock()
+		return wsResponse{}, fmt.Errorf("timed out waiting for %s response", op)
+	}
+}
+
+func (t *wsTransport) Create(data map[string]interface{}) (*Session, error) {
+	resp, err := t.call("create", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(resp.Payload, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (t *wsTransport) Get(id string) (*Session, error) {
+	resp, err := t.call("get", map[string]string{"id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(resp.Payload, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (t *wsTransport) Delete(id string) error {
+	_, err := t.call("delete", map[string]string{"id": id})
+	return err
+}
+
+func (t *wsTransport) Health() error {
+	_, err := t.call("health", nil)
+	return err
+}